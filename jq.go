@@ -0,0 +1,106 @@
+package gojsonq
+
+import (
+	"fmt"
+
+	"github.com/itchyny/gojq"
+)
+
+// Jq compiles expr as a jq program (see github.com/itchyny/gojq) and runs it
+// over the currently scoped jsonContent, collecting every yielded value.
+// This lets fluent calls and jq programs mix, e.g.
+// jq.From("users").Jq(".[] | select(.age > 30) | {name, email}").
+func (j *JSONQ) Jq(expr string) ([]interface{}, error) {
+	iter, err := j.jqRun(expr)
+	if err != nil {
+		return nil, err
+	}
+	result := []interface{}{}
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return result, nil
+		}
+		if err, ok := v.(error); ok {
+			return nil, fmt.Errorf("gojsonq: %v", err)
+		}
+		result = append(result, v)
+	}
+}
+
+// JqFirst runs expr the same way Jq does and returns only the first yielded
+// value, or nil if the program produced nothing.
+func (j *JSONQ) JqFirst(expr string) (interface{}, error) {
+	iter, err := j.jqRun(expr)
+	if err != nil {
+		return nil, err
+	}
+	v, ok := iter.Next()
+	if !ok {
+		return nil, nil
+	}
+	if err, ok := v.(error); ok {
+		return nil, fmt.Errorf("gojsonq: %v", err)
+	}
+	return v, nil
+}
+
+// JqIter runs expr the same way Jq does but streams results on a channel
+// instead of collecting them, so a large result set doesn't have to be held
+// in memory all at once. The channel is closed once the program is
+// exhausted or an error occurs; errors are recorded via addError.
+func (j *JSONQ) JqIter(expr string) (<-chan interface{}, error) {
+	iter, err := j.jqRun(expr)
+	if err != nil {
+		return nil, err
+	}
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for {
+			v, ok := iter.Next()
+			if !ok {
+				return
+			}
+			if err, ok := v.(error); ok {
+				j.addError(fmt.Errorf("gojsonq: %v", err))
+				return
+			}
+			out <- v
+		}
+	}()
+	return out, nil
+}
+
+// jqRun compiles expr and starts it against the scoped jsonContent (not
+// rootJSONContent, so a preceding From() narrows what the jq program sees),
+// with every Macro operator registered as a jq built-in of the same name.
+func (j *JSONQ) jqRun(expr string) (gojq.Iter, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return nil, fmt.Errorf("gojsonq: %v", err)
+	}
+	code, err := gojq.Compile(query, j.jqMacroOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("gojsonq: %v", err)
+	}
+	return code.Run(j.jsonContent), nil
+}
+
+// jqMacroOptions exposes every Macro-registered QueryFunc to gojq as a
+// 2-arity function, so Macro("myop", fn) becomes callable as myop(x; y)
+// from inside a jq program.
+func (j *JSONQ) jqMacroOptions() []gojq.CompilerOption {
+	opts := make([]gojq.CompilerOption, 0, len(j.macroOperators))
+	for _, name := range j.macroOperators {
+		fn := j.queryMap[name]
+		opts = append(opts, gojq.WithFunction(name, 2, 2, func(_ interface{}, args []interface{}) interface{} {
+			ok, err := fn(args[0], args[1])
+			if err != nil {
+				return err
+			}
+			return ok
+		}))
+	}
+	return opts
+}
@@ -0,0 +1,72 @@
+package gojsonq
+
+import "testing"
+
+func TestJqRunsOverScopedJSONContent(t *testing.T) {
+	j := New().JSONString(`{"users":[{"name":"John","age":25},{"name":"Jane","age":35}]}`).From("users")
+
+	got, err := j.Jq(".[] | select(.age > 30) | .name")
+	if err != nil {
+		t.Fatalf("Jq: %v", err)
+	}
+	if len(got) != 1 || got[0] != "Jane" {
+		t.Fatalf("got %v, want [Jane]", got)
+	}
+}
+
+func TestJqFirstReturnsOnlyTheFirstYieldedValue(t *testing.T) {
+	j := New().JSONString(`{"users":[{"name":"John"},{"name":"Jane"}]}`).From("users")
+
+	got, err := j.JqFirst(".[].name")
+	if err != nil {
+		t.Fatalf("JqFirst: %v", err)
+	}
+	if got != "John" {
+		t.Fatalf("got %v, want John", got)
+	}
+}
+
+func TestJqErrorsOnAnInvalidProgram(t *testing.T) {
+	j := New().JSONString(`{}`)
+
+	if _, err := j.Jq("{"); err == nil {
+		t.Fatalf("expected an error for an unparsable jq program")
+	}
+}
+
+func TestJqErrorsOnAYieldedRuntimeError(t *testing.T) {
+	j := New().JSONString(`null`)
+
+	if _, err := j.Jq("1/0"); err == nil {
+		t.Fatalf("expected a division-by-zero value yielded by the program to surface as an error")
+	}
+}
+
+func TestJqIterRecordsYieldedErrorOnJSONQ(t *testing.T) {
+	j := New().JSONString(`null`)
+
+	out, err := j.JqIter("1/0")
+	if err != nil {
+		t.Fatalf("JqIter: %v", err)
+	}
+	for range out {
+	}
+	if j.Error() == nil {
+		t.Fatalf("expected JqIter to record the yielded runtime error via addError")
+	}
+}
+
+func TestMacroOperatorIsCallableFromAJqProgram(t *testing.T) {
+	j := New().JSONString(`null`)
+	j.Macro("myeq", func(x, y interface{}) (bool, error) {
+		return x == y, nil
+	})
+
+	got, err := j.Jq("myeq(1; 1)")
+	if err != nil {
+		t.Fatalf("Jq: %v", err)
+	}
+	if len(got) != 1 || got[0] != true {
+		t.Fatalf("got %v, want [true]", got)
+	}
+}
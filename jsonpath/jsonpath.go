@@ -0,0 +1,655 @@
+// Package jsonpath implements a small JSONPath engine used by gojsonq's
+// FromPath/FindPath entry points. An expression is tokenized into segments
+// ($, .., *, [start:end:step], [0,2,4], [?(...)]), each segment is compiled
+// into a matcher, and Evaluate drives a worklist based stack machine: a list
+// of (node, path) pairs is advanced segment by segment, with a recursive
+// descent segment expanding the worklist to every descendant.
+package jsonpath
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompareFunc evaluates a single filter comparison, e.g. the ">" in
+// "@.age>30". gojsonq passes its own queryMap operators in here so filter
+// predicates share the exact comparison semantics Where uses. A nil
+// CompareFunc falls back to a small set of built-in comparisons.
+type CompareFunc func(operator string, left, right interface{}) (bool, error)
+
+// Match is a single node matched by an Expr, paired with the path it was
+// found at (e.g. "$.store.book[2].title").
+type Match struct {
+	Path  string
+	Value interface{}
+}
+
+// Expr is a compiled JSONPath expression. It holds no reference to any
+// particular document, so the same Expr can be evaluated repeatedly against
+// different documents, including across a JSONQ's Copy()'d instances.
+type Expr struct {
+	segments []segment
+}
+
+// Compile parses a JSONPath expression such as
+// "$.store.book[?(@.price<10 && @.category=='fiction')].title" into a
+// reusable Expr.
+func Compile(path string) (*Expr, error) {
+	segs, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Expr{segments: segs}, nil
+}
+
+// Evaluate runs the expression against root and returns every matched node
+// together with its path. cmp may be nil, in which case filter predicates
+// fall back to defaultCompare.
+func (e *Expr) Evaluate(root interface{}, cmp CompareFunc) ([]Match, error) {
+	work := []node{{path: "$", value: root}}
+	for _, sg := range e.segments {
+		next := make([]node, 0, len(work))
+		for _, nd := range work {
+			matched, err := applySegment(sg, nd, cmp)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, matched...)
+		}
+		work = next
+	}
+	matches := make([]Match, 0, len(work))
+	for _, nd := range work {
+		matches = append(matches, Match{Path: nd.path, Value: nd.value})
+	}
+	return matches, nil
+}
+
+// Find evaluates the expression and returns only the matched values,
+// discarding location information.
+func (e *Expr) Find(root interface{}) ([]interface{}, error) {
+	matches, err := e.Evaluate(root, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]interface{}, 0, len(matches))
+	for _, m := range matches {
+		out = append(out, m.Value)
+	}
+	return out, nil
+}
+
+// node is a worklist entry: a value paired with the path it was reached by.
+type node struct {
+	path  string
+	value interface{}
+}
+
+type segmentKind int
+
+const (
+	segRoot segmentKind = iota
+	segKey
+	segWildcard
+	segRecursive
+	segUnionIndex
+	segUnionKey
+	segSlice
+	segFilter
+)
+
+// segment is a single compiled step of a JSONPath expression.
+type segment struct {
+	kind   segmentKind
+	key    string      // segKey
+	keys   []string    // segUnionKey
+	idx    []int       // segUnionIndex
+	slice  sliceArg    // segSlice
+	filter *filterExpr // segFilter
+}
+
+type sliceArg struct {
+	start, end, step int
+	hasStart, hasEnd bool
+}
+
+// parse turns a JSONPath expression into a list of compiled segments.
+func parse(path string) ([]segment, error) {
+	if len(path) == 0 || path[0] != '$' {
+		return nil, fmt.Errorf("jsonpath: expression must start with '$', got %q", path)
+	}
+	segs := []segment{{kind: segRoot}}
+	i, n := 1, len(path)
+	for i < n {
+		switch {
+		case path[i] == '.' && i+1 < n && path[i+1] == '.':
+			i += 2
+			segs = append(segs, segment{kind: segRecursive})
+			start := i
+			for i < n && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			if name := path[start:i]; name != "" {
+				if name == "*" {
+					segs = append(segs, segment{kind: segWildcard})
+				} else {
+					segs = append(segs, segment{kind: segKey, key: name})
+				}
+			}
+		case path[i] == '.':
+			i++
+			start := i
+			for i < n && path[i] != '.' && path[i] != '[' {
+				i++
+			}
+			name := path[start:i]
+			if name == "" {
+				return nil, fmt.Errorf("jsonpath: empty segment in %q", path)
+			}
+			if name == "*" {
+				segs = append(segs, segment{kind: segWildcard})
+			} else {
+				segs = append(segs, segment{kind: segKey, key: name})
+			}
+		case path[i] == '[':
+			end, err := matchBracket(path, i)
+			if err != nil {
+				return nil, err
+			}
+			sg, err := compileBracket(path[i+1 : end])
+			if err != nil {
+				return nil, err
+			}
+			segs = append(segs, sg)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf("jsonpath: unexpected character %q at offset %d in %q", path[i], i, path)
+		}
+	}
+	return segs, nil
+}
+
+// matchBracket returns the index of the ']' that closes the '[' at open,
+// ignoring brackets and separators that appear inside quoted strings.
+func matchBracket(path string, open int) (int, error) {
+	depth := 0
+	var inQuote byte
+	for i := open; i < len(path); i++ {
+		c := path[i]
+		if inQuote != 0 {
+			if c == inQuote {
+				inQuote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			inQuote = c
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("jsonpath: unterminated '[' in %q", path[open:])
+}
+
+// compileBracket compiles the content between a pair of brackets into a
+// wildcard, filter, slice or union segment.
+func compileBracket(body string) (segment, error) {
+	body = strings.TrimSpace(body)
+	switch {
+	case body == "*":
+		return segment{kind: segWildcard}, nil
+	case strings.HasPrefix(body, "?(") && strings.HasSuffix(body, ")"):
+		fe, err := parseFilter(body[2 : len(body)-1])
+		if err != nil {
+			return segment{}, err
+		}
+		return segment{kind: segFilter, filter: fe}, nil
+	case strings.Contains(body, ":"):
+		sl, err := parseSlice(body)
+		if err != nil {
+			return segment{}, err
+		}
+		return segment{kind: segSlice, slice: sl}, nil
+	default:
+		parts := strings.Split(body, ",")
+		idx := make([]int, 0, len(parts))
+		keys := make([]string, 0, len(parts))
+		allInt := true
+		for _, p := range parts {
+			p = strings.Trim(strings.TrimSpace(p), "'\"")
+			if v, err := strconv.Atoi(p); err == nil {
+				idx = append(idx, v)
+			} else {
+				allInt = false
+			}
+			keys = append(keys, p)
+		}
+		if allInt {
+			return segment{kind: segUnionIndex, idx: idx}, nil
+		}
+		return segment{kind: segUnionKey, keys: keys}, nil
+	}
+}
+
+// parseSlice compiles a "[start:end:step]" body, every part of which is
+// optional (Python-style slicing semantics).
+func parseSlice(body string) (sliceArg, error) {
+	parts := strings.Split(body, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return sliceArg{}, fmt.Errorf("jsonpath: invalid slice %q", body)
+	}
+	var sl sliceArg
+	sl.step = 1
+	if s := strings.TrimSpace(parts[0]); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return sliceArg{}, fmt.Errorf("jsonpath: invalid slice start in %q", body)
+		}
+		sl.start, sl.hasStart = v, true
+	}
+	if s := strings.TrimSpace(parts[1]); s != "" {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return sliceArg{}, fmt.Errorf("jsonpath: invalid slice end in %q", body)
+		}
+		sl.end, sl.hasEnd = v, true
+	}
+	if len(parts) == 3 {
+		if s := strings.TrimSpace(parts[2]); s != "" {
+			v, err := strconv.Atoi(s)
+			if err != nil {
+				return sliceArg{}, fmt.Errorf("jsonpath: invalid slice step in %q", body)
+			}
+			if v == 0 {
+				return sliceArg{}, fmt.Errorf("jsonpath: slice step can not be zero")
+			}
+			sl.step = v
+		}
+	}
+	return sl, nil
+}
+
+// applySegment advances a single worklist entry through one compiled
+// segment, returning the entries that replace it.
+func applySegment(sg segment, nd node, cmp CompareFunc) ([]node, error) {
+	switch sg.kind {
+	case segRoot:
+		return []node{nd}, nil
+	case segRecursive:
+		return collectDescendants(nd), nil
+	case segKey:
+		if m, ok := nd.value.(map[string]interface{}); ok {
+			if v, present := m[sg.key]; present {
+				return []node{{path: nd.path + "." + sg.key, value: v}}, nil
+			}
+		}
+		return nil, nil
+	case segWildcard:
+		return expandWildcard(nd), nil
+	case segUnionIndex:
+		arr, ok := nd.value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		out := make([]node, 0, len(sg.idx))
+		for _, i := range sg.idx {
+			idx := i
+			if idx < 0 {
+				idx += len(arr)
+			}
+			if idx < 0 || idx >= len(arr) {
+				continue
+			}
+			out = append(out, node{path: fmt.Sprintf("%s[%d]", nd.path, idx), value: arr[idx]})
+		}
+		return out, nil
+	case segUnionKey:
+		m, ok := nd.value.(map[string]interface{})
+		if !ok {
+			return nil, nil
+		}
+		out := make([]node, 0, len(sg.keys))
+		for _, k := range sg.keys {
+			if v, present := m[k]; present {
+				out = append(out, node{path: nd.path + "." + k, value: v})
+			}
+		}
+		return out, nil
+	case segSlice:
+		arr, ok := nd.value.([]interface{})
+		if !ok {
+			return nil, nil
+		}
+		return sliceNodes(nd, arr, sg.slice), nil
+	case segFilter:
+		return applyFilter(nd, sg.filter, cmp)
+	}
+	return nil, fmt.Errorf("jsonpath: unknown segment kind")
+}
+
+// collectDescendants returns nd followed by every descendant reachable
+// through maps and slices, depth-first. This is what makes ".." expand the
+// worklist to the whole subtree.
+func collectDescendants(nd node) []node {
+	result := []node{nd}
+	switch v := nd.value.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			result = append(result, collectDescendants(node{path: nd.path + "." + k, value: val})...)
+		}
+	case []interface{}:
+		for i, val := range v {
+			result = append(result, collectDescendants(node{path: fmt.Sprintf("%s[%d]", nd.path, i), value: val})...)
+		}
+	}
+	return result
+}
+
+func expandWildcard(nd node) []node {
+	switch v := nd.value.(type) {
+	case map[string]interface{}:
+		out := make([]node, 0, len(v))
+		for k, val := range v {
+			out = append(out, node{path: nd.path + "." + k, value: val})
+		}
+		return out
+	case []interface{}:
+		out := make([]node, 0, len(v))
+		for i, val := range v {
+			out = append(out, node{path: fmt.Sprintf("%s[%d]", nd.path, i), value: val})
+		}
+		return out
+	}
+	return nil
+}
+
+// sliceNodes applies Python-style slicing (negative indices count from the
+// end, step may be negative) to an array node.
+func sliceNodes(nd node, arr []interface{}, sl sliceArg) []node {
+	n := len(arr)
+	step := sl.step
+	if step == 0 {
+		step = 1
+	}
+	// Defaults are sign-aware: a forward step walks the whole array from the
+	// front, a negative step walks it from the back, with end=-1 meaning
+	// "one past the front" rather than a normalized index (an explicit end
+	// of -1 still means "the last element", handled below by normalizeIndex).
+	start, end := 0, n
+	if step < 0 {
+		start, end = n-1, -1
+	}
+	if sl.hasStart {
+		start = normalizeIndex(sl.start, n)
+		if step < 0 && sl.start < -n {
+			start = -1
+		}
+	}
+	if sl.hasEnd {
+		end = normalizeIndex(sl.end, n)
+		if step < 0 && sl.end < -n {
+			end = -1
+		}
+	}
+	out := []node{}
+	if step > 0 {
+		for i := start; i < end && i < n; i += step {
+			if i < 0 {
+				continue
+			}
+			out = append(out, node{path: fmt.Sprintf("%s[%d]", nd.path, i), value: arr[i]})
+		}
+	} else {
+		for i := start; i > end && i >= 0; i += step {
+			if i >= n {
+				continue
+			}
+			out = append(out, node{path: fmt.Sprintf("%s[%d]", nd.path, i), value: arr[i]})
+		}
+	}
+	return out
+}
+
+func normalizeIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// applyFilter tests a [?(...)] predicate against every element of an array
+// node, or once against a single object node.
+func applyFilter(nd node, fe *filterExpr, cmp CompareFunc) ([]node, error) {
+	switch v := nd.value.(type) {
+	case []interface{}:
+		out := []node{}
+		for i, elem := range v {
+			ok, err := evalFilter(fe, elem, cmp)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				out = append(out, node{path: fmt.Sprintf("%s[%d]", nd.path, i), value: elem})
+			}
+		}
+		return out, nil
+	case map[string]interface{}:
+		ok, err := evalFilter(fe, v, cmp)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return []node{nd}, nil
+		}
+		return nil, nil
+	default:
+		return nil, nil
+	}
+}
+
+// filterExpr is the AST of a [?(...)] predicate: either a leaf comparison
+// ("@.age>30") or a boolean combination of two sub-expressions.
+type filterExpr struct {
+	isLeaf bool
+
+	// leaf
+	field    string
+	operator string
+	value    interface{}
+
+	// boolean node ("&&" / "||")
+	op          string
+	left, right *filterExpr
+}
+
+func evalFilter(fe *filterExpr, obj interface{}, cmp CompareFunc) (bool, error) {
+	if fe.isLeaf {
+		m, ok := obj.(map[string]interface{})
+		if !ok {
+			return false, nil
+		}
+		val, present := m[fe.field]
+		if fe.operator == "exists" {
+			return present, nil
+		}
+		if !present {
+			return false, nil
+		}
+		if cmp != nil {
+			return cmp(fe.operator, val, fe.value)
+		}
+		return defaultCompare(fe.operator, val, fe.value)
+	}
+	l, err := evalFilter(fe.left, obj, cmp)
+	if err != nil {
+		return false, err
+	}
+	if fe.op == "&&" && !l {
+		return false, nil
+	}
+	if fe.op == "||" && l {
+		return true, nil
+	}
+	return evalFilter(fe.right, obj, cmp)
+}
+
+// parseFilter compiles the body of a [?(...)] predicate, e.g.
+// "@.age>30 && @.name=='x'". && binds tighter than ||, matching the usual
+// boolean precedence.
+func parseFilter(body string) (*filterExpr, error) {
+	var orNode *filterExpr
+	for _, orPart := range splitTopLevel(body, "||") {
+		var andNode *filterExpr
+		for _, cmpPart := range splitTopLevel(orPart, "&&") {
+			leaf, err := parseComparison(strings.TrimSpace(cmpPart))
+			if err != nil {
+				return nil, err
+			}
+			if andNode == nil {
+				andNode = leaf
+			} else {
+				andNode = &filterExpr{op: "&&", left: andNode, right: leaf}
+			}
+		}
+		if orNode == nil {
+			orNode = andNode
+		} else {
+			orNode = &filterExpr{op: "||", left: orNode, right: andNode}
+		}
+	}
+	if orNode == nil {
+		return nil, fmt.Errorf("jsonpath: empty filter predicate")
+	}
+	return orNode, nil
+}
+
+// splitTopLevel splits s on sep, ignoring occurrences of sep inside quotes.
+func splitTopLevel(s, sep string) []string {
+	parts := []string{}
+	var inQuote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inQuote != 0 {
+			if c == inQuote {
+				inQuote = 0
+			}
+			continue
+		}
+		if c == '\'' || c == '"' {
+			inQuote = c
+			continue
+		}
+		if strings.HasPrefix(s[i:], sep) {
+			parts = append(parts, s[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+	parts = append(parts, s[start:])
+	return parts
+}
+
+var filterOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// parseComparison compiles a single leaf of a filter predicate, either a
+// comparison ("@.age>30") or a bare existence check ("@.discount").
+func parseComparison(s string) (*filterExpr, error) {
+	for _, op := range filterOperators {
+		if idx := strings.Index(s, op); idx >= 0 {
+			field, err := parseFieldRef(s[:idx])
+			if err != nil {
+				return nil, err
+			}
+			return &filterExpr{isLeaf: true, field: field, operator: op, value: parseLiteral(s[idx+len(op):])}, nil
+		}
+	}
+	field, err := parseFieldRef(s)
+	if err != nil {
+		return nil, err
+	}
+	return &filterExpr{isLeaf: true, field: field, operator: "exists"}, nil
+}
+
+func parseFieldRef(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "@.") {
+		return "", fmt.Errorf("jsonpath: filter field must start with '@.', got %q", s)
+	}
+	return s[2:], nil
+}
+
+func parseLiteral(s string) interface{} {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+// defaultCompare implements the comparisons filter predicates need when no
+// CompareFunc is supplied.
+func defaultCompare(operator string, left, right interface{}) (bool, error) {
+	lf, lok := toFloat(left)
+	rf, rok := toFloat(right)
+	if lok && rok {
+		switch operator {
+		case "==":
+			return lf == rf, nil
+		case "!=":
+			return lf != rf, nil
+		case ">":
+			return lf > rf, nil
+		case "<":
+			return lf < rf, nil
+		case ">=":
+			return lf >= rf, nil
+		case "<=":
+			return lf <= rf, nil
+		}
+	}
+	ls := fmt.Sprintf("%v", left)
+	rs := fmt.Sprintf("%v", right)
+	switch operator {
+	case "==":
+		return ls == rs, nil
+	case "!=":
+		return ls != rs, nil
+	default:
+		return false, fmt.Errorf("jsonpath: operator %s is not supported for non-numeric values", operator)
+	}
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,161 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func mustDecode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("decode %s: %v", s, err)
+	}
+	return v
+}
+
+func findValues(t *testing.T, expr string, doc interface{}) []interface{} {
+	t.Helper()
+	e, err := Compile(expr)
+	if err != nil {
+		t.Fatalf("Compile(%q): %v", expr, err)
+	}
+	values, err := e.Find(doc)
+	if err != nil {
+		t.Fatalf("Find(%q): %v", expr, err)
+	}
+	return values
+}
+
+func TestCompileRejectsExpressionsNotStartingWithRoot(t *testing.T) {
+	if _, err := Compile("store.book"); err == nil {
+		t.Fatalf("expected an error for an expression missing the leading '$'")
+	}
+}
+
+func TestKeyAndWildcardSegments(t *testing.T) {
+	doc := mustDecode(t, `{"store":{"book":[{"title":"A"},{"title":"B"}]}}`)
+
+	got := findValues(t, "$.store.book[*].title", doc)
+	want := []interface{}{"A", "B"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestUnionIndexAndUnionKey(t *testing.T) {
+	doc := mustDecode(t, `{"a":1,"b":2,"c":3,"list":["x","y","z"]}`)
+
+	gotIdx := findValues(t, "$.list[0,2]", doc)
+	if !reflect.DeepEqual(gotIdx, []interface{}{"x", "z"}) {
+		t.Fatalf("union index: got %v", gotIdx)
+	}
+
+	gotKeys := findValues(t, "$['a','c']", doc)
+	sort.Slice(gotKeys, func(i, j int) bool { return gotKeys[i].(float64) < gotKeys[j].(float64) })
+	if !reflect.DeepEqual(gotKeys, []interface{}{1.0, 3.0}) {
+		t.Fatalf("union key: got %v", gotKeys)
+	}
+}
+
+func TestSliceSegment(t *testing.T) {
+	doc := mustDecode(t, `[0,1,2,3,4,5]`)
+
+	got := findValues(t, "$[1:4]", doc)
+	want := []interface{}{1.0, 2.0, 3.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	gotNeg := findValues(t, "$[-2:]", doc)
+	wantNeg := []interface{}{4.0, 5.0}
+	if !reflect.DeepEqual(gotNeg, wantNeg) {
+		t.Fatalf("negative slice: got %v, want %v", gotNeg, wantNeg)
+	}
+}
+
+func TestSliceSegmentWithNegativeStep(t *testing.T) {
+	doc := mustDecode(t, `[0,1,2,3,4,5]`)
+
+	got := findValues(t, "$[::-1]", doc)
+	want := []interface{}{5.0, 4.0, 3.0, 2.0, 1.0, 0.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("reversed slice: got %v, want %v", got, want)
+	}
+
+	gotBounded := findValues(t, "$[:2:-1]", doc)
+	wantBounded := []interface{}{5.0, 4.0, 3.0}
+	if !reflect.DeepEqual(gotBounded, wantBounded) {
+		t.Fatalf("bounded reverse slice: got %v, want %v", gotBounded, wantBounded)
+	}
+}
+
+func TestRecursiveDescent(t *testing.T) {
+	doc := mustDecode(t, `{"a":{"id":1},"b":{"c":{"id":2}}}`)
+
+	got := findValues(t, "$..id", doc)
+	sort.Slice(got, func(i, j int) bool { return got[i].(float64) < got[j].(float64) })
+	want := []interface{}{1.0, 2.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFilterPredicateWithDefaultCompare(t *testing.T) {
+	doc := mustDecode(t, `{"book":[{"price":8,"category":"fiction"},{"price":25,"category":"fiction"},{"price":5,"category":"other"}]}`)
+
+	got := findValues(t, "$.book[?(@.price<10 && @.category=='fiction')].price", doc)
+	want := []interface{}{8.0}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFilterPredicateUsesSuppliedCompareFunc(t *testing.T) {
+	doc := mustDecode(t, `{"book":[{"price":8},{"price":25}]}`)
+	e, err := Compile("$.book[?(@.price>10)].price")
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+
+	var called bool
+	cmp := func(operator string, left, right interface{}) (bool, error) {
+		called = true
+		lf, _ := left.(float64)
+		rf, _ := right.(float64)
+		if operator == ">" {
+			return lf > rf, nil
+		}
+		return false, nil
+	}
+	matches, err := e.Evaluate(doc, cmp)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if !called {
+		t.Fatalf("expected the supplied CompareFunc to be used instead of defaultCompare")
+	}
+	if len(matches) != 1 || matches[0].Value != 25.0 {
+		t.Fatalf("got %v", matches)
+	}
+}
+
+func TestEvaluateRecordsPath(t *testing.T) {
+	doc := mustDecode(t, `{"store":{"book":[{"title":"A"}]}}`)
+	e, err := Compile("$.store.book[0].title")
+	if err != nil {
+		t.Fatalf("Compile: %v", err)
+	}
+	matches, err := e.Evaluate(doc, nil)
+	if err != nil {
+		t.Fatalf("Evaluate: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1", len(matches))
+	}
+	if want := "$.store.book[0].title"; matches[0].Path != want {
+		t.Fatalf("got path %q, want %q", matches[0].Path, want)
+	}
+}
@@ -7,13 +7,17 @@ import (
 	"io"
 	"io/ioutil"
 	"strings"
+
+	"github.com/aland-zhang/gojsonq/jsonpath"
 )
 
 // New returns a new instance of JSONQ
 func New() *JSONQ {
-	return &JSONQ{
+	j := &JSONQ{
 		queryMap: loadDefaultQueryMap(),
 	}
+	registerMissingOperators(j.queryMap)
+	return j
 }
 
 // empty represents an empty result
@@ -33,9 +37,27 @@ type JSONQ struct {
 	rootJSONContent interface{}     // original decoded json data
 	jsonContent     interface{}     // copy of original decoded json data for further processing
 	queryIndex      int
-	queries         []([]query) // nested queries
-	attributes      []string    // select attributes
-	errors          []error     // contains all the errors when processing
+	queries         []([]query)      // nested queries
+	attributes      []string         // select attributes
+	errors          []error          // contains all the errors when processing
+	pathExpr        *jsonpath.Expr   // compiled expression of the last FromPath/FindPath call
+	pathMatches     []jsonpath.Match // matches (value + location) of the last FromPath/FindPath call
+	macroOperators  []string         // names of operators registered via Macro, in registration order
+	owned           bool             // true if rootJSONContent is exclusively owned by this instance (see Copy/ensureOwned)
+	streamSource    io.Reader        // source configured via StreamReader/StreamFile, consumed by Stream
+	streamCloser    io.Closer        // non-nil when streamSource was opened by StreamFile and needs closing
+	rawMode         bool             // true once Raw() is called; the next File/JSONString/Reader skips decode()
+}
+
+// Raw puts JSONQ into raw mode: the next File, JSONString or Reader call
+// stores the source bytes and returns without decoding them, so a
+// subsequent GetRaw can scan straight to the field you need instead of
+// paying for a full json.Unmarshal of the whole document. Any method that
+// needs rootJSONContent (Where, Get, Sum, ...) still requires a normal,
+// non-raw load.
+func (j *JSONQ) Raw() *JSONQ {
+	j.rawMode = true
+	return j
 }
 
 // String statisfy stringer interface
@@ -50,13 +72,18 @@ func (j *JSONQ) decode() *JSONQ {
 		return j.addError(err)
 	}
 	j.jsonContent = j.rootJSONContent
+	j.owned = true
 	return j
 }
 
 // Copy returns a new fresh instance of JSONQ with the original copy of data so that you can do
-// concurrent operation on the same data without being decoded again
+// concurrent operation on the same data without being decoded again. rootJSONContent itself isn't
+// cloned here: both instances are marked unowned so the first one to call a mutating method (Set,
+// Delete, ...) transparently clones it before writing, keeping concurrent mutation paths isolated.
 func (j *JSONQ) Copy() *JSONQ {
 	tmp := *j
+	j.owned = false
+	tmp.owned = false
 	return tmp.reset()
 }
 
@@ -67,12 +94,18 @@ func (j *JSONQ) File(filename string) *JSONQ {
 		return j.addError(err)
 	}
 	j.raw = bb
+	if j.rawMode {
+		return j
+	}
 	return j.decode() // handle error
 }
 
 // JSONString reads the json content from valid json string
 func (j *JSONQ) JSONString(json string) *JSONQ {
 	j.raw = []byte(json)
+	if j.rawMode {
+		return j
+	}
 	return j.decode() // handle error
 }
 
@@ -85,6 +118,9 @@ func (j *JSONQ) Reader(r io.Reader) *JSONQ {
 	}
 	j.raw = buf.Bytes()
 	buf.Reset() // reset the buffer
+	if j.rawMode {
+		return j
+	}
 	return j.decode()
 }
 
@@ -114,12 +150,19 @@ func (j *JSONQ) Macro(operator string, fn QueryFunc) *JSONQ {
 		j.addError(fmt.Errorf("%s is already registered in query map", operator))
 	}
 	j.queryMap[operator] = fn
+	j.macroOperators = append(j.macroOperators, operator)
 	return j
 }
 
 // From seeks the json content to provided node. e.g: "users.[0]"  or "users.[0].name"
 func (j *JSONQ) From(node string) *JSONQ {
 	j.node = node
+	if j.raw == nil && j.rootJSONContent == nil {
+		// Nothing has been decoded yet, which means a streaming source was
+		// configured via StreamReader/StreamFile: resolution of node happens
+		// lazily, token by token, once Stream is called.
+		return j
+	}
 	return j.findNode(node)
 }
 
@@ -276,20 +319,37 @@ func (j *JSONQ) findInMap(vm map[string]interface{}) []interface{} {
 	for _, qList := range j.queries {
 		andPassed := true
 		for _, q := range qList {
-			if mv, o := vm[q.key]; o {
-				cf, ok := j.queryMap[q.operator]
-				if !ok {
-					j.addError(fmt.Errorf("invalid operator %s", q.operator))
-					return result
+			// A key that's absent entirely is only passed through as Missing
+			// to operators that know how to look for it (isMissing/isNull and
+			// friends, and any operator registered via Macro); every other
+			// operator treats an absent key as SQL-style unknown, which never
+			// satisfies the clause, rather than silently comparing Missing
+			// against q.value.
+			mv, present := vm[q.key]
+			if !present {
+				isMacro := false
+				for _, name := range j.macroOperators {
+					if name == q.operator {
+						isMacro = true
+						break
+					}
 				}
-				qb, err := cf(mv, q.value)
-				if err != nil {
-					j.addError(err)
+				if !missingAwareOperators[q.operator] && !isMacro {
+					andPassed = false
+					continue
 				}
-				andPassed = andPassed && qb
-			} else {
-				andPassed = false
+				mv = Missing
+			}
+			cf, ok := j.queryMap[q.operator]
+			if !ok {
+				j.addError(fmt.Errorf("invalid operator %s", q.operator))
+				return result
+			}
+			qb, err := cf(mv, q.value)
+			if err != nil {
+				j.addError(err)
 			}
+			andPassed = andPassed && qb
 		}
 		orPassed = orPassed || andPassed
 	}
@@ -0,0 +1,68 @@
+package gojsonq
+
+// missingType is a dedicated sentinel type (rather than a plain struct{} or
+// string) so Missing can never accidentally compare equal to a value a real
+// document could contain.
+type missingType struct{}
+
+// Missing is passed to query operators in place of a map key that doesn't
+// exist at all, as opposed to nil for a key that exists with a null value.
+// Where("x", "=", nil) and WhereNil only ever match a present-but-null key;
+// use WhereMissing, or compare against Missing from inside a Macro, to
+// match an absent one.
+var Missing interface{} = missingType{}
+
+// registerMissingOperators adds the isMissing/isNull family to m, letting
+// queries distinguish an absent key from one whose value is nil.
+func registerMissingOperators(m map[string]QueryFunc) {
+	m["isMissing"] = isMissing
+	m["isNotMissing"] = isNotMissing
+	m["isNull"] = isNull
+	m["isNotNull"] = isNotNull
+}
+
+// missingAwareOperators are the only built-in operators findInMap hands the
+// Missing sentinel to for an absent key (operators registered via Macro get
+// it as well); every other built-in comparator (=, !=,
+// in, contains, ...) never sees Missing at all, since passing it through
+// would let e.g. WhereNotEqual/WhereNotIn wrongly match records where the
+// key doesn't exist instead of treating the clause as unsatisfied.
+var missingAwareOperators = map[string]bool{
+	"isMissing":    true,
+	"isNotMissing": true,
+	"isNull":       true,
+	"isNotNull":    true,
+}
+
+// isMissing is the "isMissing" operator: true only when the key was absent
+// from the object entirely.
+func isMissing(x, y interface{}) (bool, error) {
+	return x == Missing, nil
+}
+
+// isNotMissing is the "isNotMissing" operator.
+func isNotMissing(x, y interface{}) (bool, error) {
+	return x != Missing, nil
+}
+
+// isNull is the "isNull" operator: true when the key is present but its
+// value is nil. A Missing key is neither null nor not-null.
+func isNull(x, y interface{}) (bool, error) {
+	return x != Missing && x == nil, nil
+}
+
+// isNotNull is the "isNotNull" operator.
+func isNotNull(x, y interface{}) (bool, error) {
+	return x != Missing && x != nil, nil
+}
+
+// WhereMissing is an alias for Where(key, "isMissing", nil): key must not
+// exist in the object at all.
+func (j *JSONQ) WhereMissing(key string) *JSONQ {
+	return j.Where(key, "isMissing", nil)
+}
+
+// WhereNotMissing is an alias for Where(key, "isNotMissing", nil).
+func (j *JSONQ) WhereNotMissing(key string) *JSONQ {
+	return j.Where(key, "isNotMissing", nil)
+}
@@ -0,0 +1,57 @@
+package gojsonq
+
+import "testing"
+
+func TestIsMissingAndIsNullDistinguishAbsentFromNull(t *testing.T) {
+	cases := []struct {
+		name string
+		fn   QueryFunc
+		x    interface{}
+		want bool
+	}{
+		{"isMissing/absent", isMissing, Missing, true},
+		{"isMissing/null", isMissing, nil, false},
+		{"isMissing/present", isMissing, "x", false},
+		{"isNotMissing/absent", isNotMissing, Missing, false},
+		{"isNull/absent", isNull, Missing, false},
+		{"isNull/null", isNull, nil, true},
+		{"isNull/present", isNull, "x", false},
+		{"isNotNull/absent", isNotNull, Missing, false},
+		{"isNotNull/present", isNotNull, "x", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := c.fn(c.x, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != c.want {
+				t.Fatalf("got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestFindInMapExcludesAbsentKeyFromNonMissingAwareOperators(t *testing.T) {
+	j := New()
+	j.queryMap = map[string]QueryFunc{
+		"!=": func(x, y interface{}) (bool, error) { return x != y, nil },
+	}
+	registerMissingOperators(j.queryMap)
+	j.Where("age", "!=", nil)
+
+	result := j.findInMap(map[string]interface{}{"name": "John"})
+	if len(result) != 0 {
+		t.Fatalf("expected an absent key to fail a non-missing-aware operator's clause, got %v", result)
+	}
+}
+
+func TestFindInMapPassesMissingThroughToMissingAwareOperators(t *testing.T) {
+	j := New()
+	j.Where("age", "isMissing", nil)
+
+	result := j.findInMap(map[string]interface{}{"name": "John"})
+	if len(result) != 1 {
+		t.Fatalf("expected isMissing to match a record with an absent key, got %v", result)
+	}
+}
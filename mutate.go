@@ -0,0 +1,281 @@
+package gojsonq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Set walks path using the same "users.[0].name" syntax as From and writes
+// value at the end of it, creating any intermediate maps/arrays that don't
+// exist yet. The mutation applies to rootJSONContent and invalidates any
+// cached query state, so a subsequent Get() reflects the change.
+func (j *JSONQ) Set(path string, value interface{}) *JSONQ {
+	return j.set(path, value, false)
+}
+
+// SetStrict is like Set but adds an error instead of creating a missing
+// intermediate map/array or key.
+func (j *JSONQ) SetStrict(path string, value interface{}) *JSONQ {
+	return j.set(path, value, true)
+}
+
+func (j *JSONQ) set(path string, value interface{}, strict bool) *JSONQ {
+	if err := j.ensureOwned(); err != nil {
+		return j.addError(err)
+	}
+	root, err := setPath(j.rootJSONContent, strings.Split(path, "."), value, strict)
+	if err != nil {
+		return j.addError(err)
+	}
+	j.rootJSONContent = root
+	j.reset()
+	return j
+}
+
+// Delete removes the value found at path. Deleting an array index shifts
+// later elements down.
+func (j *JSONQ) Delete(path string) *JSONQ {
+	if err := j.ensureOwned(); err != nil {
+		return j.addError(err)
+	}
+	root, err := deletePath(j.rootJSONContent, strings.Split(path, "."))
+	if err != nil {
+		return j.addError(err)
+	}
+	j.rootJSONContent = root
+	j.reset()
+	return j
+}
+
+// ArrayAppend appends values to the array found at path, creating the array
+// (and any missing intermediates) if it doesn't exist yet.
+func (j *JSONQ) ArrayAppend(path string, values ...interface{}) *JSONQ {
+	if err := j.ensureOwned(); err != nil {
+		return j.addError(err)
+	}
+	cur, err := getPath(j.rootJSONContent, strings.Split(path, "."))
+	if err != nil {
+		cur = nil
+	}
+	arr, ok := cur.([]interface{})
+	if !ok && cur != nil {
+		return j.addError(fmt.Errorf("%s is not an array", path))
+	}
+	arr = append(arr, values...)
+	return j.Set(path, arr)
+}
+
+// ArrayInsert inserts value at index inside the array found at path,
+// shifting later elements up.
+func (j *JSONQ) ArrayInsert(path string, index int, value interface{}) *JSONQ {
+	if err := j.ensureOwned(); err != nil {
+		return j.addError(err)
+	}
+	cur, err := getPath(j.rootJSONContent, strings.Split(path, "."))
+	if err != nil {
+		return j.addError(err)
+	}
+	arr, ok := cur.([]interface{})
+	if !ok {
+		return j.addError(fmt.Errorf("%s is not an array", path))
+	}
+	if index < 0 || index > len(arr) {
+		return j.addError(fmt.Errorf("index %d out of range", index))
+	}
+	arr = append(arr, nil)
+	copy(arr[index+1:], arr[index:])
+	arr[index] = value
+	return j.Set(path, arr)
+}
+
+// Merge shallow-merges other into the map found at path, overwriting
+// matching keys. The map is created if it doesn't exist yet.
+func (j *JSONQ) Merge(path string, other map[string]interface{}) *JSONQ {
+	if err := j.ensureOwned(); err != nil {
+		return j.addError(err)
+	}
+	cur, err := getPath(j.rootJSONContent, strings.Split(path, "."))
+	if err != nil {
+		cur = map[string]interface{}{}
+	}
+	mp, ok := cur.(map[string]interface{})
+	if !ok {
+		return j.addError(fmt.Errorf("%s is not an object", path))
+	}
+	for k, v := range other {
+		mp[k] = v
+	}
+	return j.Set(path, mp)
+}
+
+// Bytes serializes the full document (rootJSONContent) back to JSON.
+func (j *JSONQ) Bytes() ([]byte, error) {
+	return json.Marshal(j.rootJSONContent)
+}
+
+// Indent serializes the full document (rootJSONContent) back to JSON with
+// the given prefix/indent, mirroring json.MarshalIndent.
+func (j *JSONQ) Indent(prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(j.rootJSONContent, prefix, indent)
+}
+
+// ensureOwned clones rootJSONContent the first time this instance mutates
+// data that might still be shared with a Copy()'d sibling, so concurrent
+// mutation paths never clobber each other.
+func (j *JSONQ) ensureOwned() error {
+	if j.owned {
+		return nil
+	}
+	cloned, err := deepCloneJSON(j.rootJSONContent)
+	if err != nil {
+		return err
+	}
+	j.rootJSONContent = cloned
+	j.owned = true
+	return nil
+}
+
+// deepCloneJSON returns a structurally independent copy of a decoded JSON
+// value (maps, slices and scalars) by round-tripping it through the
+// encoding/json package.
+func deepCloneJSON(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out interface{}
+	if err := json.Unmarshal(b, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// getPath reads the value found at segs inside node without mutating it.
+func getPath(node interface{}, segs []string) (interface{}, error) {
+	for _, seg := range segs {
+		if isIndex(seg) {
+			idx, err := getIndex(seg)
+			if err != nil {
+				return nil, err
+			}
+			arr, ok := node.([]interface{})
+			if !ok || idx < 0 || idx >= len(arr) {
+				return nil, fmt.Errorf("index %d does not exist", idx)
+			}
+			node = arr[idx]
+			continue
+		}
+		mp, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("key %q does not exist", seg)
+		}
+		v, present := mp[seg]
+		if !present {
+			return nil, fmt.Errorf("key %q does not exist", seg)
+		}
+		node = v
+	}
+	return node, nil
+}
+
+// setPath writes value at segs inside node, auto-creating intermediate
+// maps/arrays unless strict is true, and returns the (possibly new) node.
+func setPath(node interface{}, segs []string, value interface{}, strict bool) (interface{}, error) {
+	if len(segs) == 0 {
+		return value, nil
+	}
+	seg, rest := segs[0], segs[1:]
+
+	if isIndex(seg) {
+		idx, err := getIndex(seg)
+		if err != nil {
+			return nil, err
+		}
+		arr, ok := node.([]interface{})
+		if !ok {
+			if node != nil {
+				return nil, fmt.Errorf("can not set index %d on a non-array node", idx)
+			}
+			if strict {
+				return nil, fmt.Errorf("index %d does not exist", idx)
+			}
+		}
+		for len(arr) <= idx {
+			arr = append(arr, nil)
+		}
+		child, err := setPath(arr[idx], rest, value, strict)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+
+	mp, ok := node.(map[string]interface{})
+	if !ok {
+		if node != nil {
+			return nil, fmt.Errorf("can not set key %q on a non-object node", seg)
+		}
+		if strict {
+			return nil, fmt.Errorf("key %q does not exist", seg)
+		}
+		mp = map[string]interface{}{}
+	}
+	if _, present := mp[seg]; strict && !present {
+		return nil, fmt.Errorf("key %q does not exist", seg)
+	}
+	child, err := setPath(mp[seg], rest, value, strict)
+	if err != nil {
+		return nil, err
+	}
+	mp[seg] = child
+	return mp, nil
+}
+
+// deletePath removes the value found at segs inside node.
+func deletePath(node interface{}, segs []string) (interface{}, error) {
+	seg, rest := segs[0], segs[1:]
+
+	if isIndex(seg) {
+		idx, err := getIndex(seg)
+		if err != nil {
+			return nil, err
+		}
+		arr, ok := node.([]interface{})
+		if !ok || idx < 0 || idx >= len(arr) {
+			return nil, fmt.Errorf("index %d does not exist", idx)
+		}
+		if len(rest) == 0 {
+			return append(arr[:idx], arr[idx+1:]...), nil
+		}
+		child, err := deletePath(arr[idx], rest)
+		if err != nil {
+			return nil, err
+		}
+		arr[idx] = child
+		return arr, nil
+	}
+
+	mp, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("key %q does not exist", seg)
+	}
+	v, present := mp[seg]
+	if !present {
+		return nil, fmt.Errorf("key %q does not exist", seg)
+	}
+	if len(rest) == 0 {
+		delete(mp, seg)
+		return mp, nil
+	}
+	child, err := deletePath(v, rest)
+	if err != nil {
+		return nil, err
+	}
+	mp[seg] = child
+	return mp, nil
+}
@@ -0,0 +1,128 @@
+package gojsonq
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetCreatesMissingIntermediates(t *testing.T) {
+	j := New().JSONString(`{}`)
+	j.Set("a.b.[2].c", "value")
+
+	got := j.Find("a.b.[2].c")
+	if got != "value" {
+		t.Fatalf("got %v, want %q", got, "value")
+	}
+	// Find narrows the instance to the found node, so reset before the next lookup.
+	b := j.Reset().Find("a.b")
+	if arr, ok := b.([]interface{}); !ok || len(arr) != 3 {
+		t.Fatalf("expected Set to grow the array to 3 elements, got %v", b)
+	}
+}
+
+func TestSetStrictErrorsOnMissingIntermediate(t *testing.T) {
+	j := New().JSONString(`{}`)
+	j.SetStrict("a.b", "value")
+
+	if j.Error() == nil {
+		t.Fatalf("expected SetStrict to error on a missing intermediate key")
+	}
+}
+
+func TestDeleteShiftsArrayIndicesDown(t *testing.T) {
+	j := New().JSONString(`{"items":["a","b","c"]}`)
+	j.Delete("items.[1]")
+
+	got := j.Find("items")
+	want := []interface{}{"a", "c"}
+	if len(got.([]interface{})) != len(want) || got.([]interface{})[0] != "a" || got.([]interface{})[1] != "c" {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestArrayInsertShiftsElementsUp(t *testing.T) {
+	j := New().JSONString(`{"items":["a","c"]}`)
+	j.ArrayInsert("items", 1, "b")
+
+	got := j.Find("items").([]interface{})
+	want := []interface{}{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestArrayAppendCreatesMissingArray(t *testing.T) {
+	j := New().JSONString(`{}`)
+	j.ArrayAppend("items", "a", "b")
+
+	got := j.Find("items").([]interface{})
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+}
+
+func TestMergeOverwritesMatchingKeysAndCreatesMissingObject(t *testing.T) {
+	j := New().JSONString(`{"user":{"name":"John","age":25}}`)
+	j.Merge("user", map[string]interface{}{"age": 26.0, "active": true})
+
+	user := j.Find("user").(map[string]interface{})
+	if user["age"] != 26.0 || user["active"] != true || user["name"] != "John" {
+		t.Fatalf("got %v", user)
+	}
+
+	j.Merge("settings", map[string]interface{}{"theme": "dark"})
+	settings, ok := j.Find("settings").(map[string]interface{})
+	if !ok || settings["theme"] != "dark" {
+		t.Fatalf("expected Merge to create the missing object, got %v", j.Get())
+	}
+}
+
+func TestBytesAndIndentSerializeTheFullDocument(t *testing.T) {
+	j := New().JSONString(`{"name":"John"}`)
+
+	b, err := j.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes: %v", err)
+	}
+	if !strings.Contains(string(b), `"name":"John"`) {
+		t.Fatalf("got %s", b)
+	}
+
+	ib, err := j.Indent("", "  ")
+	if err != nil {
+		t.Fatalf("Indent: %v", err)
+	}
+	if !strings.Contains(string(ib), "\n") {
+		t.Fatalf("expected Indent to produce multi-line output, got %s", ib)
+	}
+}
+
+func TestCopyMutationDoesNotClobberOriginal(t *testing.T) {
+	orig := New().JSONString(`{"user":{"name":"John"}}`)
+	sibling := orig.Copy()
+
+	sibling.Set("user.name", "Jane")
+
+	if got := orig.Find("user.name"); got != "John" {
+		t.Fatalf("mutating the Copy()'d sibling changed the original: got %v, want John", got)
+	}
+	if got := sibling.Find("user.name"); got != "Jane" {
+		t.Fatalf("got %v, want Jane", got)
+	}
+}
+
+func TestCopyThenOriginalMutationDoesNotClobberSibling(t *testing.T) {
+	orig := New().JSONString(`{"user":{"name":"John"}}`)
+	sibling := orig.Copy()
+
+	orig.Set("user.name", "Jane")
+
+	if got := sibling.Find("user.name"); got != "John" {
+		t.Fatalf("mutating the original changed the Copy()'d sibling: got %v, want John", got)
+	}
+}
@@ -0,0 +1,77 @@
+package gojsonq
+
+import (
+	"fmt"
+
+	"github.com/aland-zhang/gojsonq/jsonpath"
+)
+
+// jsonPathOperatorAlias maps the symbol a jsonpath filter predicate uses to
+// the operator name it is registered under in queryMap, e.g. "@.age==30"
+// should run through the same comparator as Where("age", "=", 30).
+var jsonPathOperatorAlias = map[string]string{
+	"==": "=",
+}
+
+// comparator adapts queryMap into a jsonpath.CompareFunc so filter
+// predicates such as "@.age>30" route through the exact same comparator
+// functions Where uses, including any operator added via Macro.
+func (j *JSONQ) comparator() jsonpath.CompareFunc {
+	return func(operator string, left, right interface{}) (bool, error) {
+		if alias, ok := jsonPathOperatorAlias[operator]; ok {
+			operator = alias
+		}
+		cf, ok := j.queryMap[operator]
+		if !ok {
+			return false, fmt.Errorf("invalid operator %s", operator)
+		}
+		return cf(left, right)
+	}
+}
+
+// FromPath seeks the json content using a full JSONPath expression, e.g.
+// "$.store.book[?(@.price<10 && @.category=='fiction')].title", and assigns
+// the matched nodes as the new jsonContent so Where/Select/Pluck can keep
+// operating on them. The compiled expression is stored on the JSONQ and
+// Copy() carries it over, but FromPath compiles expr on every call and no
+// other method evaluates the stored expression.
+func (j *JSONQ) FromPath(expr string) *JSONQ {
+	exp, err := jsonpath.Compile(expr)
+	if err != nil {
+		return j.addError(err)
+	}
+	j.pathExpr = exp
+	return j.evalPath()
+}
+
+// evalPath runs the compiled path expression against rootJSONContent and
+// records both the matched values (as jsonContent) and their locations.
+func (j *JSONQ) evalPath() *JSONQ {
+	matches, err := j.pathExpr.Evaluate(j.rootJSONContent, j.comparator())
+	if err != nil {
+		return j.addError(err)
+	}
+	j.pathMatches = matches
+	values := make([]interface{}, 0, len(matches))
+	for _, m := range matches {
+		values = append(values, m.Value)
+	}
+	j.jsonContent = values
+	return j
+}
+
+// FindPath is a shortcut for FromPath(expr).Get().
+func (j *JSONQ) FindPath(expr string) interface{} {
+	return j.FromPath(expr).Get()
+}
+
+// Paths returns the dot/bracket locations of the nodes matched by the most
+// recent FromPath/FindPath call, in match order. Where clauses applied
+// afterwards filter Get()'s result but not these paths.
+func (j *JSONQ) Paths() []string {
+	paths := make([]string, 0, len(j.pathMatches))
+	for _, m := range j.pathMatches {
+		paths = append(paths, m.Path)
+	}
+	return paths
+}
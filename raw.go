@@ -0,0 +1,193 @@
+package gojsonq
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetRaw walks path using the same "users.[0].name" syntax as Find, but
+// scans j.raw directly instead of decoding it into rootJSONContent, and
+// returns the exact byte slice of the matched value without allocating a
+// Go map. Combined with Raw() (so the initial File/JSONString/Reader call
+// skips decode() entirely), this is the hot path for hit-a-single-field
+// workloads. The caller can json.Unmarshal the result into a typed struct,
+// or pass string(raw) back into JSONString to re-enter the query API.
+func (j *JSONQ) GetRaw(path string) (json.RawMessage, error) {
+	raw, err := scanPath(j.raw, strings.Split(path, "."))
+	if err != nil {
+		return nil, fmt.Errorf("gojsonq: %v", err)
+	}
+	return raw, nil
+}
+
+// scanPath walks segs into data (a raw JSON byte slice) one segment at a
+// time, scanning forward over irrelevant keys/elements instead of decoding
+// them, and returns the exact byte slice of the final match.
+func scanPath(data []byte, segs []string) (json.RawMessage, error) {
+	data = data[skipSpaceIdx(data, 0):]
+	for _, seg := range segs {
+		var err error
+		if isIndex(seg) {
+			idx, ierr := getIndex(seg)
+			if ierr != nil {
+				return nil, ierr
+			}
+			data, err = scanArrayIndex(data, idx)
+		} else {
+			data, err = scanObjectKey(data, seg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		data = data[skipSpaceIdx(data, 0):]
+	}
+	return json.RawMessage(data), nil
+}
+
+// scanObjectKey expects data to start with '{' and returns the raw bytes of
+// key's value, skipping every non-matching member's value unread.
+func scanObjectKey(data []byte, key string) ([]byte, error) {
+	if len(data) == 0 || data[0] != '{' {
+		return nil, fmt.Errorf("expected an object while seeking key %q", key)
+	}
+	i := skipSpaceIdx(data, 1)
+	for i < len(data) && data[i] != '}' {
+		if data[i] != '"' {
+			return nil, fmt.Errorf("malformed object while seeking key %q", key)
+		}
+		keyEnd, err := scanStringEnd(data, i)
+		if err != nil {
+			return nil, err
+		}
+		var k string
+		if err := json.Unmarshal(data[i:keyEnd], &k); err != nil {
+			return nil, err
+		}
+		i = skipSpaceIdx(data, keyEnd)
+		if i >= len(data) || data[i] != ':' {
+			return nil, fmt.Errorf("expected ':' after key %q", k)
+		}
+		i = skipSpaceIdx(data, i+1)
+		valEnd, err := skipRawValue(data, i)
+		if err != nil {
+			return nil, err
+		}
+		if k == key {
+			return data[i:valEnd], nil
+		}
+		i = skipSpaceIdx(data, valEnd)
+		if i < len(data) && data[i] == ',' {
+			i = skipSpaceIdx(data, i+1)
+			continue
+		}
+		break
+	}
+	return nil, fmt.Errorf("key %q does not exist", key)
+}
+
+// scanArrayIndex expects data to start with '[' and returns the raw bytes of
+// the element at idx, counting commas at depth 0 instead of decoding the
+// elements that precede it.
+func scanArrayIndex(data []byte, idx int) ([]byte, error) {
+	if len(data) == 0 || data[0] != '[' {
+		return nil, fmt.Errorf("expected an array while seeking index %d", idx)
+	}
+	i := skipSpaceIdx(data, 1)
+	for n := 0; i < len(data) && data[i] != ']'; n++ {
+		valEnd, err := skipRawValue(data, i)
+		if err != nil {
+			return nil, err
+		}
+		if n == idx {
+			return data[i:valEnd], nil
+		}
+		i = skipSpaceIdx(data, valEnd)
+		if i < len(data) && data[i] == ',' {
+			i = skipSpaceIdx(data, i+1)
+			continue
+		}
+		break
+	}
+	return nil, fmt.Errorf("index %d does not exist", idx)
+}
+
+// skipRawValue returns the offset just past the JSON value starting at
+// data[i], without decoding it: strings are scanned to their closing quote
+// (honoring backslash escapes) and objects/arrays by brace/bracket depth, so
+// deeply nested values are skipped in a single pass over the bytes.
+func skipRawValue(data []byte, i int) (int, error) {
+	if i >= len(data) {
+		return 0, fmt.Errorf("unexpected end of input")
+	}
+	switch data[i] {
+	case '"':
+		return scanStringEnd(data, i)
+	case '{', '[':
+		open, close := data[i], byte('}')
+		if open == '[' {
+			close = ']'
+		}
+		depth := 1
+		i++
+		for depth > 0 {
+			if i >= len(data) {
+				return 0, fmt.Errorf("unexpected end of input")
+			}
+			switch data[i] {
+			case '"':
+				end, err := scanStringEnd(data, i)
+				if err != nil {
+					return 0, err
+				}
+				i = end
+				continue
+			case open:
+				depth++
+			case close:
+				depth--
+			}
+			i++
+		}
+		return i, nil
+	default:
+		// number, true, false or null: ends at the next structural byte.
+		for i < len(data) {
+			switch data[i] {
+			case ',', '}', ']', ' ', '\t', '\n', '\r':
+				return i, nil
+			}
+			i++
+		}
+		return i, nil
+	}
+}
+
+// scanStringEnd returns the offset just past the closing quote of the JSON
+// string starting at data[i] (which must be '"'), advancing two bytes at a
+// time over backslash escapes so an escaped quote doesn't end the scan early.
+func scanStringEnd(data []byte, i int) (int, error) {
+	for i++; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// skipSpaceIdx returns the index of the next non-whitespace byte in data at
+// or after i.
+func skipSpaceIdx(data []byte, i int) int {
+	for i < len(data) {
+		switch data[i] {
+		case ' ', '\t', '\n', '\r':
+			i++
+			continue
+		}
+		break
+	}
+	return i
+}
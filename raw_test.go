@@ -0,0 +1,75 @@
+package gojsonq
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetRawObjectAndArrayPaths(t *testing.T) {
+	j := &JSONQ{raw: []byte(`{"users":[{"name":"John"},{"name":"Jane"}],"count":2}`)}
+
+	raw, err := j.GetRaw("users.[1].name")
+	if err != nil {
+		t.Fatalf("GetRaw: %v", err)
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		t.Fatalf("unmarshal raw value: %v", err)
+	}
+	if name != "Jane" {
+		t.Fatalf("got %q, want %q", name, "Jane")
+	}
+
+	raw, err = j.GetRaw("count")
+	if err != nil {
+		t.Fatalf("GetRaw: %v", err)
+	}
+	if string(raw) != "2" {
+		t.Fatalf("got %q, want %q", raw, "2")
+	}
+}
+
+func TestGetRawSkipsEscapedQuotesAndNestedValues(t *testing.T) {
+	j := &JSONQ{raw: []byte(`{"a":{"nested":"ignore \"this\" value"},"b":"target"}`)}
+
+	raw, err := j.GetRaw("b")
+	if err != nil {
+		t.Fatalf("GetRaw: %v", err)
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if s != "target" {
+		t.Fatalf("got %q, want %q", s, "target")
+	}
+}
+
+func TestGetRawMissingKeyAndIndex(t *testing.T) {
+	j := &JSONQ{raw: []byte(`{"a":[1,2]}`)}
+
+	if _, err := j.GetRaw("missing"); err == nil {
+		t.Fatalf("expected an error for a missing key")
+	}
+	if _, err := j.GetRaw("a.[5]"); err == nil {
+		t.Fatalf("expected an error for an out of range index")
+	}
+}
+
+func TestRawModeSkipsDecode(t *testing.T) {
+	j := New().Raw().JSONString(`{"name":"John"}`)
+	if j.rootJSONContent != nil {
+		t.Fatalf("Raw() should skip decode(), but rootJSONContent was populated")
+	}
+	raw, err := j.GetRaw("name")
+	if err != nil {
+		t.Fatalf("GetRaw: %v", err)
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if name != "John" {
+		t.Fatalf("got %q, want %q", name, "John")
+	}
+}
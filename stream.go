@@ -0,0 +1,212 @@
+package gojsonq
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// StreamReader prepares JSONQ for streaming evaluation over r: tokens are
+// read one at a time via encoding/json.Decoder instead of decoding the
+// whole payload up front, so a multi-GB NDJSON or JSON array source can be
+// queried while only the element currently being examined is held in
+// memory. Chain From to pick the array to stream (e.g. From("items")) and
+// any Where predicates, then call Stream to consume matching elements.
+// Without From, the source is treated as NDJSON: every top-level value is
+// its own record.
+func (j *JSONQ) StreamReader(r io.Reader) *JSONQ {
+	j.streamSource = r
+	return j
+}
+
+// StreamFile is like StreamReader but reads from a physical file.
+func (j *JSONQ) StreamFile(filename string) *JSONQ {
+	f, err := os.Open(filename)
+	if err != nil {
+		return j.addError(err)
+	}
+	j.streamCloser = f
+	return j.StreamReader(f)
+}
+
+// Stream runs the configured From path and Where predicates against the
+// streaming source token by token, sending every matching element on the
+// returned channel as soon as it is decoded. The channel is closed once the
+// source is exhausted; any decode error is recorded via addError.
+func (j *JSONQ) Stream() <-chan interface{} {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		if j.streamCloser != nil {
+			defer j.streamCloser.Close()
+		}
+		if j.streamSource == nil {
+			j.addError(fmt.Errorf("gojsonq: Stream called without StreamReader/StreamFile"))
+			return
+		}
+		if err := j.streamDecode(out); err != nil {
+			j.addError(err)
+		}
+	}()
+	return out
+}
+
+// streamDecode walks the configured source with a single json.Decoder,
+// descending to the array named by From (if any) before handing control to
+// the NDJSON loop, which both paths share once positioned on a sequence of
+// independent values.
+func (j *JSONQ) streamDecode(out chan<- interface{}) error {
+	dec := json.NewDecoder(j.streamSource)
+	if j.node == "" {
+		return streamValues(dec, j, out)
+	}
+	if err := descendToArray(dec, strings.Split(j.node, ".")); err != nil {
+		return err
+	}
+	return streamValues(dec, j, out)
+}
+
+// streamValues decodes a sequence of independent top-level/array-element
+// JSON values from dec, forwarding the ones that satisfy j's Where
+// predicates. This is also how NDJSON is supported: encoding/json.Decoder
+// treats whitespace between top-level values as a plain separator.
+func streamValues(dec *json.Decoder, j *JSONQ, out chan<- interface{}) error {
+	for dec.More() {
+		var v interface{}
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		if j.streamMatches(v) {
+			out <- v
+		}
+	}
+	return nil
+}
+
+// streamMatches reports whether v satisfies the registered Where/OrWhere
+// clauses, reusing the same findInMap logic Get/First/... rely on so streamed
+// results follow identical matching rules. A value that isn't a JSON object
+// is not checked against the clauses and always matches.
+func (j *JSONQ) streamMatches(v interface{}) bool {
+	if len(j.queries) == 0 {
+		return true
+	}
+	mv, ok := v.(map[string]interface{})
+	if !ok {
+		return true
+	}
+	return len(j.findInMap(mv)) > 0
+}
+
+// descendToArray advances dec past every token up to and including the
+// opening '[' of the array found by walking segs from the document root,
+// e.g. segs == []string{"items"} positions the decoder right after a
+// top-level {"items": [ ... leaving the array's elements unread. A segment
+// in the "[N]" bracket-index form (the same syntax From/findNode accept) is
+// resolved with seekArrayIndex instead of treating it as a literal object
+// key, so From("users.[0].items") descends through an array element first.
+func descendToArray(dec *json.Decoder, segs []string) error {
+	for _, seg := range segs {
+		var err error
+		if isIndex(seg) {
+			idx, ierr := getIndex(seg)
+			if ierr != nil {
+				return ierr
+			}
+			err = seekArrayIndex(dec, idx)
+		} else {
+			err = seekObjectKey(dec, seg)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("gojsonq: %q is not an array", strings.Join(segs, "."))
+	}
+	return nil
+}
+
+// seekObjectKey reads tokens until it finds key in the next JSON object dec
+// encounters, skipping every other member's value, and leaves the decoder
+// positioned right before that key's value.
+func seekObjectKey(dec *json.Decoder, key string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("gojsonq: expected an object while seeking %q", key)
+	}
+	for dec.More() {
+		nameTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if name, _ := nameTok.(string); name == key {
+			return nil
+		}
+		if err := skipValue(dec); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("gojsonq: key %q not found", key)
+}
+
+// seekArrayIndex reads tokens until it reaches the element at idx inside the
+// next JSON array dec encounters, skipping every earlier element without
+// decoding it, and leaves the decoder positioned right before that
+// element's value, mirroring seekObjectKey's postcondition for an object
+// segment.
+func seekArrayIndex(dec *json.Decoder, idx int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("gojsonq: expected an array while seeking index %d", idx)
+	}
+	for i := 0; dec.More(); i++ {
+		if i == idx {
+			return nil
+		}
+		if err := skipValue(dec); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("gojsonq: index %d does not exist", idx)
+}
+
+// skipValue consumes one full JSON value (scalar, object or array) from dec
+// without decoding it into a Go value.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if _, ok := tok.(json.Delim); !ok {
+		return nil // scalar, already consumed
+	}
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
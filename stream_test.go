@@ -0,0 +1,72 @@
+package gojsonq
+
+import (
+	"strings"
+	"testing"
+)
+
+func collectStream(t *testing.T, j *JSONQ) []interface{} {
+	t.Helper()
+	var got []interface{}
+	for v := range j.Stream() {
+		got = append(got, v)
+	}
+	if err := j.Error(); err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	return got
+}
+
+func TestStreamNDJSON(t *testing.T) {
+	src := `{"name":"John"}
+{"name":"Jane"}
+`
+	j := New().StreamReader(strings.NewReader(src))
+	got := collectStream(t, j)
+	if len(got) != 2 {
+		t.Fatalf("got %d records, want 2", len(got))
+	}
+	first, ok := got[0].(map[string]interface{})
+	if !ok || first["name"] != "John" {
+		t.Fatalf("got %v, want first record name John", got[0])
+	}
+}
+
+func TestStreamDescendsToNamedArray(t *testing.T) {
+	src := `{"users":[{"name":"John"},{"name":"Jane"}]}`
+	j := New().StreamReader(strings.NewReader(src)).From("users")
+	got := collectStream(t, j)
+	if len(got) != 2 {
+		t.Fatalf("got %d records, want 2", len(got))
+	}
+	last, ok := got[1].(map[string]interface{})
+	if !ok || last["name"] != "Jane" {
+		t.Fatalf("got %v, want last record name Jane", got[1])
+	}
+}
+
+func TestStreamDescendsThroughArrayIndex(t *testing.T) {
+	src := `{"groups":[{"items":[{"name":"A"},{"name":"B"}]},{"items":[{"name":"C"}]}]}`
+	j := New().StreamReader(strings.NewReader(src)).From("groups.[1].items")
+	got := collectStream(t, j)
+	if len(got) != 1 {
+		t.Fatalf("got %d records, want 1", len(got))
+	}
+	rec, ok := got[0].(map[string]interface{})
+	if !ok || rec["name"] != "C" {
+		t.Fatalf("got %v, want record name C", got[0])
+	}
+}
+
+func TestStreamAppliesWherePredicates(t *testing.T) {
+	src := `{"users":[{"name":"John","age":25},{"name":"Jane","age":30}]}`
+	j := New().StreamReader(strings.NewReader(src)).From("users").Where("age", ">", 28.0)
+	got := collectStream(t, j)
+	if len(got) != 1 {
+		t.Fatalf("got %d records, want 1", len(got))
+	}
+	rec, ok := got[0].(map[string]interface{})
+	if !ok || rec["name"] != "Jane" {
+		t.Fatalf("got %v, want record name Jane", got[0])
+	}
+}